@@ -0,0 +1,127 @@
+// Command dmxp-consumer runs a standalone DMXP consumer that prints every
+// received message and exposes Prometheus metrics and a liveness probe.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/neerajchowdary889/DMXP-MPMC/pkg/dmxp"
+)
+
+func main() {
+	var (
+		channels   = flag.String("channels", "100", "comma-separated channel IDs to consume")
+		addr       = flag.String("addr", ":9090", "address to serve /metrics and /healthz on")
+		staleAfter = flag.Duration("stale-after", 30*time.Second, "mark /healthz unhealthy if no message has arrived within this window")
+		workers    = flag.Int("workers", 0, "dispatch worker goroutines (default: one per channel)")
+	)
+	flag.Parse()
+
+	channelIDs, err := parseChannelIDs(*channels)
+	if err != nil {
+		log.Fatalf("invalid -channels: %v", err)
+	}
+
+	instrumentation := dmxp.NewInstrumentation(prometheus.DefaultRegisterer)
+
+	groupOpts := []dmxp.GroupOption{dmxp.WithGroupInstrumentation(instrumentation)}
+	if *workers > 0 {
+		groupOpts = append(groupOpts, dmxp.WithWorkers(*workers))
+	}
+
+	group, err := dmxp.NewConsumerGroup(channelIDs, func(_ context.Context, msg dmxp.Message) error {
+		fmt.Printf("Go Received: '%s'\n", msg.Payload)
+		fmt.Printf("   Metadata -> Channel: %d, PID: %d, MsgID: %d\n", msg.ChannelID, msg.SenderPID, msg.MessageID)
+		return nil
+	}, groupOpts...)
+	if err != nil {
+		log.Fatalf("creating consumer group: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", healthzHandler(instrumentation, *staleAfter))
+
+	srv := &http.Server{Addr: *addr, Handler: mux}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("http server: %v", err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("dmxp-consumer listening on %s, consuming channels %v", *addr, channelIDs)
+	if err := group.Run(ctx); err != nil {
+		log.Fatalf("consumer group: %v", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = srv.Shutdown(shutdownCtx)
+}
+
+type healthzResponse struct {
+	Healthy           bool    `json:"healthy"`
+	SecondsSinceLast  float64 `json:"seconds_since_last_message"`
+	StaleAfterSeconds float64 `json:"stale_after_seconds"`
+}
+
+// healthzHandler reports unhealthy once no message has been received for
+// longer than staleAfter, mirroring a simple heartbeat/ping liveness check.
+// It also reports unhealthy until the first message has been received.
+func healthzHandler(in *dmxp.Instrumentation, staleAfter time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		last := in.LastReceivedAt()
+
+		resp := healthzResponse{StaleAfterSeconds: staleAfter.Seconds()}
+		if last.IsZero() {
+			resp.Healthy = false
+			resp.SecondsSinceLast = -1
+		} else {
+			age := time.Since(last)
+			resp.SecondsSinceLast = age.Seconds()
+			resp.Healthy = age <= staleAfter
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if !resp.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func parseChannelIDs(csv string) ([]uint32, error) {
+	parts := strings.Split(csv, ",")
+	ids := make([]uint32, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("channel %q: %w", p, err)
+		}
+		ids = append(ids, uint32(n))
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no channel IDs given")
+	}
+	return ids, nil
+}
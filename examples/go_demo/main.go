@@ -1,73 +1,46 @@
+// Command go_demo is a thin bridge daemon: it loads a sink config, fans in
+// the configured channels with a dmxp.ConsumerGroup, and forwards every
+// received message to the configured sinks.
 package main
 
-/*
-#cgo LDFLAGS: -L../../target/debug -ldmxp_kvcache
-#include <stdlib.h>
-#include <stdint.h>
-
-typedef struct {
-    uint64_t message_id;
-    uint64_t timestamp_ns;
-    uint32_t channel_id;
-    uint32_t message_type;
-    uint32_t sender_pid;
-    uint16_t sender_runtime;
-    uint16_t flags;
-    uint32_t payload_len;
-} FFIMessageMeta;
-
-// Forward declarations of Rust FFI functions
-void* dmxp_consumer_new(uint32_t channel_id);
-int32_t dmxp_consumer_receive_ext(void* handle, int32_t timeout_ms, uint8_t* out_buf, size_t* out_len, FFIMessageMeta* out_meta);
-void dmxp_consumer_free(void* handle);
-*/
-import "C"
 import (
-	"fmt"
-	"os"
-	"unsafe"
+	"context"
+	"flag"
+	"log"
+	"os/signal"
+	"syscall"
+
+	"github.com/neerajchowdary889/DMXP-MPMC/pkg/dmxp"
+	"github.com/neerajchowdary889/DMXP-MPMC/pkg/dmxp/sink"
 )
 
 func main() {
-    channelID := uint32(100)
-	fmt.Printf("Go Consumer connecting to channel %d...\n", channelID)
+	configPath := flag.String("config", "go_demo.yaml", "path to a sink config file (.yaml or .json)")
+	flag.Parse()
 
-	// Create Consumer
-	handle := C.dmxp_consumer_new(C.uint32_t(channelID))
-	if handle == nil {
-		fmt.Println("Failed to create consumer")
-		os.Exit(1)
+	cfg, err := sink.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("loading config: %v", err)
 	}
-	defer C.dmxp_consumer_free(handle)
-
-	fmt.Println("Waiting for messages from Python...")
 
-	buffer := make([]byte, 1024)
-	var meta C.FFIMessageMeta
+	sinks, err := sink.Build(cfg)
+	if err != nil {
+		log.Fatalf("building sinks: %v", err)
+	}
+	defer sinks.Close()
 
-	for {
-		outLen := C.size_t(len(buffer))
-		timeoutMs := C.int32_t(1000) // 1 second timeout
+	group, err := dmxp.NewConsumerGroup(cfg.Channels, func(ctx context.Context, msg dmxp.Message) error {
+		return sinks.Write(ctx, msg)
+	})
+	if err != nil {
+		log.Fatalf("creating consumer group: %v", err)
+	}
 
-		// Call Rust FFI
-		// We pass address of buffer[0], address of outLen, address of meta
-		res := C.dmxp_consumer_receive_ext(
-			handle,
-			timeoutMs,
-			(*C.uint8_t)(unsafe.Pointer(&buffer[0])),
-			&outLen,
-			&meta,
-		)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-		if res == 0 { // DMXP_SUCCESS
-			msg := string(buffer[:outLen])
-			fmt.Printf("Go Received: '%s'\n", msg)
-			fmt.Printf("   Metadata -> PID: %d, MsgID: %d\n", meta.sender_pid, meta.message_id)
-		} else if res == -7 { // DMXP_ERROR_TIMEOUT
-			// timeout, just loop
-			continue
-		} else {
-			fmt.Printf("Error receiving: %d\n", res)
-		}
+	log.Printf("consuming channels %v into %d sink(s)", cfg.Channels, len(cfg.Sinks))
+	if err := group.Run(ctx); err != nil {
+		log.Fatalf("consumer group: %v", err)
 	}
 }
@@ -0,0 +1,175 @@
+package dmxp
+
+import (
+	"context"
+	"sync"
+	"unsafe"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// minReceiveBufferSize is the floor Receive resets a buffer to if it is
+// ever found empty, since doubling a zero-length buffer never grows it.
+const minReceiveBufferSize = 1024
+
+// Consumer reads messages for a single channel from the shared-memory
+// transport. A Consumer is not safe for concurrent use by multiple
+// goroutines; use a ConsumerGroup to fan in several channels.
+type Consumer struct {
+	channelID uint32
+	cfg       config
+
+	// bufPool holds reusable receive buffers, seeded at cfg.initialBufCap,
+	// so Receive doesn't allocate on every call in the steady state.
+	// Buffers are grown (never shrunk) and put back regardless of their
+	// final size.
+	bufPool sync.Pool
+
+	mu     sync.Mutex
+	handle unsafe.Pointer
+	closed bool
+}
+
+// NewConsumer opens a consumer handle for channelID. The returned Consumer
+// must be closed with Close when no longer needed.
+func NewConsumer(channelID uint32, opts ...Option) (*Consumer, error) {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	handle, err := newConsumerHandle(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Consumer{
+		channelID: channelID,
+		cfg:       cfg,
+		handle:    handle,
+	}
+	c.bufPool.New = func() any {
+		buf := make([]byte, cfg.initialBufCap)
+		return &buf
+	}
+	return c, nil
+}
+
+// Receive blocks until a message arrives, ctx is done, or the Consumer is
+// closed. ErrTimeout from the transport is retried internally and never
+// surfaces to the caller; to bound how long Receive waits, cancel ctx (for
+// example with context.WithTimeout) instead of relying on a transport
+// timeout.
+func (c *Consumer) Receive(ctx context.Context) (Message, error) {
+	bufp := c.bufPool.Get().(*[]byte)
+	defer c.bufPool.Put(bufp)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return Message{}, err
+		}
+
+		if len(*bufp) == 0 {
+			// Defends against a misconfigured initialBufCap; doubling a
+			// zero-length buffer would never grow it.
+			*bufp = make([]byte, minReceiveBufferSize)
+		}
+
+		n, meta, err := c.receiveOnce(*bufp)
+		if err == ErrTimeout {
+			continue
+		}
+		if ffiErr, ok := err.(*ffiError); ok && ffiErr.code == codeBufferTooSmall {
+			*bufp = make([]byte, len(*bufp)*2)
+			continue
+		}
+		if err != nil {
+			return Message{}, c.traceReceiveError(ctx, err)
+		}
+
+		payload := make([]byte, n)
+		copy(payload, (*bufp)[:n])
+
+		spanCtx := ctx
+		if meta.flags&flagHasTraceContext != 0 && len(payload) >= traceHeaderLen {
+			spanCtx = decodeTraceHeader(ctx, payload[:traceHeaderLen])
+			payload = payload[traceHeaderLen:]
+		}
+
+		_, span := tracer().Start(spanCtx, "dmxp.receive", trace.WithAttributes(
+			messageSpanAttributes(meta.channelID, meta.messageID, meta.senderPID, meta.senderRuntime)...,
+		))
+		span.SetStatus(codes.Ok, "")
+		span.End()
+
+		if c.cfg.instrumentation != nil {
+			c.cfg.instrumentation.recordReceive(meta.channelID, meta.senderRuntime, meta.timestampNS)
+		}
+
+		return Message{
+			MessageID:     meta.messageID,
+			TimestampNS:   meta.timestampNS,
+			ChannelID:     meta.channelID,
+			MessageType:   meta.messageType,
+			SenderPID:     meta.senderPID,
+			SenderRuntime: meta.senderRuntime,
+			Flags:         meta.flags,
+			Payload:       payload,
+		}, nil
+	}
+}
+
+// traceReceiveError records a span for a Receive call that failed outright
+// (as opposed to a retried ErrTimeout or buffer-growth retry), so failed
+// receives show up in tracing the same way Producer.Send's failures do. No
+// trace header has been read at this point, so the span is parented by ctx
+// alone.
+func (c *Consumer) traceReceiveError(ctx context.Context, err error) error {
+	_, span := tracer().Start(ctx, "dmxp.receive", trace.WithAttributes(
+		attribute.Int64("dmxp.channel_id", int64(c.channelID)),
+	))
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	span.End()
+
+	if c.cfg.instrumentation != nil {
+		c.cfg.instrumentation.recordError(err)
+	}
+	return err
+}
+
+// receiveOnce issues one bounded-timeout FFI call so that Receive can
+// re-check ctx.Done() at c.cfg.pollInterval granularity instead of
+// blocking indefinitely inside cgo.
+func (c *Consumer) receiveOnce(buf []byte) (int, ffiMeta, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return 0, ffiMeta{}, ErrClosed
+	}
+
+	timeoutMs := int32(c.cfg.pollInterval / 1e6)
+	if timeoutMs <= 0 {
+		timeoutMs = 1
+	}
+
+	return receiveInto(c.handle, timeoutMs, buf)
+}
+
+// Close releases the underlying consumer handle. It is safe to call Close
+// more than once.
+func (c *Consumer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	freeConsumerHandle(c.handle)
+	c.handle = nil
+	return nil
+}
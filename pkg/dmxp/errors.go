@@ -0,0 +1,66 @@
+package dmxp
+
+import "fmt"
+
+// Error codes returned by the underlying Rust transport, mirrored here so
+// that callers never need to know the FFI's magic numbers.
+const (
+	codeSuccess        = 0
+	codeTimeout        = -7
+	codeClosed         = -2
+	codeInvalidArg     = -3
+	codeBufferTooSmall = -4
+)
+
+// Sentinel errors returned by Consumer.Receive and Producer.Send. Callers
+// should use errors.Is against these rather than inspecting FFI result
+// codes directly.
+var (
+	// ErrTimeout marks a single bounded FFI poll that ended before a
+	// message arrived. Consumer.Receive and ReceiveZeroCopy retry it
+	// internally, so it does not reach their callers; a canceled or expired
+	// context is reported as ctx.Err() instead.
+	ErrTimeout = fmt.Errorf("dmxp: receive timed out")
+
+	// ErrClosed is returned once a Consumer or Producer has been closed
+	// and is used again.
+	ErrClosed = fmt.Errorf("dmxp: handle is closed")
+
+	// ErrInvalidArgument is returned when the FFI layer rejects the
+	// arguments passed to it (e.g. an unknown channel ID).
+	ErrInvalidArgument = fmt.Errorf("dmxp: invalid argument")
+)
+
+// ffiError wraps an FFI result code that does not map to one of the
+// sentinel errors above, preserving the raw code for logging/metrics.
+type ffiError struct {
+	op   string
+	code int32
+}
+
+func (e *ffiError) Error() string {
+	return fmt.Sprintf("dmxp: %s failed with code %d", e.op, e.code)
+}
+
+// Code returns the raw FFI result code, useful for building symbolic
+// metric labels without string parsing.
+func (e *ffiError) Code() int32 {
+	return e.code
+}
+
+// errFromCode translates a raw FFI result code from op into a Go error,
+// using the sentinel errors where possible.
+func errFromCode(op string, code int32) error {
+	switch code {
+	case codeSuccess:
+		return nil
+	case codeTimeout:
+		return ErrTimeout
+	case codeClosed:
+		return ErrClosed
+	case codeInvalidArg:
+		return ErrInvalidArgument
+	default:
+		return &ffiError{op: op, code: code}
+	}
+}
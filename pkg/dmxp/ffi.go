@@ -0,0 +1,136 @@
+package dmxp
+
+/*
+#cgo LDFLAGS: -L${SRCDIR}/../../target/debug -ldmxp_kvcache
+#include <stdlib.h>
+#include <stdint.h>
+
+typedef struct {
+    uint64_t message_id;
+    uint64_t timestamp_ns;
+    uint32_t channel_id;
+    uint32_t message_type;
+    uint32_t sender_pid;
+    uint16_t sender_runtime;
+    uint16_t flags;
+    uint32_t payload_len;
+} FFIMessageMeta;
+
+// Forward declarations of Rust FFI functions.
+void* dmxp_consumer_new(uint32_t channel_id);
+int32_t dmxp_consumer_receive_ext(void* handle, int32_t timeout_ms, uint8_t* out_buf, size_t* out_len, FFIMessageMeta* out_meta);
+void dmxp_consumer_free(void* handle);
+
+void* dmxp_producer_new(uint32_t channel_id);
+int32_t dmxp_producer_send_ext(void* handle, uint32_t message_type, uint16_t flags, const uint8_t* payload, size_t payload_len);
+void dmxp_producer_free(void* handle);
+*/
+import "C"
+import "unsafe"
+
+// This file is the only place in the package that imports "C". Every other
+// file talks to the transport through the plain-Go helpers below so that
+// the cgo preamble and its magic numbers stay in one place.
+
+// ffiMeta is the Go-side copy of FFIMessageMeta, decoupled from the C type
+// so the rest of the package never needs to import "C".
+type ffiMeta struct {
+	messageID     uint64
+	timestampNS   uint64
+	channelID     uint32
+	messageType   uint32
+	senderPID     uint32
+	senderRuntime uint16
+	flags         uint16
+}
+
+func newConsumerHandle(channelID uint32) (unsafe.Pointer, error) {
+	h := C.dmxp_consumer_new(C.uint32_t(channelID))
+	if h == nil {
+		return nil, &ffiError{op: "dmxp_consumer_new", code: -1}
+	}
+	return unsafe.Pointer(h), nil
+}
+
+func freeConsumerHandle(handle unsafe.Pointer) {
+	C.dmxp_consumer_free(handle)
+}
+
+// receiveInto issues a single, bounded-timeout receive call into buf,
+// returning the number of bytes written and the decoded metadata. Callers
+// are responsible for growing buf and retrying on an *ffiError whose code
+// is codeBufferTooSmall.
+func receiveInto(handle unsafe.Pointer, timeoutMs int32, buf []byte) (int, ffiMeta, error) {
+	return receiveIntoPtr(handle, timeoutMs, unsafe.Pointer(&buf[0]), len(buf))
+}
+
+// receiveIntoPtr is the pointer-based core of receiveInto, shared with the
+// zero-copy path in zerocopy.go, which writes into a C.malloc'd slab
+// instead of a Go slice.
+func receiveIntoPtr(handle unsafe.Pointer, timeoutMs int32, ptr unsafe.Pointer, capacity int) (int, ffiMeta, error) {
+	var meta C.FFIMessageMeta
+	outLen := C.size_t(capacity)
+
+	res := C.dmxp_consumer_receive_ext(
+		handle,
+		C.int32_t(timeoutMs),
+		(*C.uint8_t)(ptr),
+		&outLen,
+		&meta,
+	)
+
+	if err := errFromCode("dmxp_consumer_receive_ext", int32(res)); err != nil {
+		return 0, ffiMeta{}, err
+	}
+
+	return int(outLen), ffiMeta{
+		messageID:     uint64(meta.message_id),
+		timestampNS:   uint64(meta.timestamp_ns),
+		channelID:     uint32(meta.channel_id),
+		messageType:   uint32(meta.message_type),
+		senderPID:     uint32(meta.sender_pid),
+		senderRuntime: uint16(meta.sender_runtime),
+		flags:         uint16(meta.flags),
+	}, nil
+}
+
+func newProducerHandle(channelID uint32) (unsafe.Pointer, error) {
+	h := C.dmxp_producer_new(C.uint32_t(channelID))
+	if h == nil {
+		return nil, &ffiError{op: "dmxp_producer_new", code: -1}
+	}
+	return unsafe.Pointer(h), nil
+}
+
+func freeProducerHandle(handle unsafe.Pointer) {
+	C.dmxp_producer_free(handle)
+}
+
+// allocSlab allocates a size-byte buffer outside the Go heap so it can be
+// aliased as a []byte without the garbage collector ever relocating or
+// reclaiming it out from under the Rust side. The caller must freeSlab it
+// exactly once.
+func allocSlab(size int) unsafe.Pointer {
+	return C.malloc(C.size_t(size))
+}
+
+func freeSlab(p unsafe.Pointer) {
+	C.free(p)
+}
+
+func sendFrom(handle unsafe.Pointer, messageType uint32, flags uint16, payload []byte) error {
+	var ptr *C.uint8_t
+	if len(payload) > 0 {
+		ptr = (*C.uint8_t)(unsafe.Pointer(&payload[0]))
+	}
+
+	res := C.dmxp_producer_send_ext(
+		handle,
+		C.uint32_t(messageType),
+		C.uint16_t(flags),
+		ptr,
+		C.size_t(len(payload)),
+	)
+
+	return errFromCode("dmxp_producer_send_ext", int32(res))
+}
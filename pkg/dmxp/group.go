@@ -0,0 +1,303 @@
+package dmxp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// receiveErrorBackoffMin/Max bound the delay receiveLoop waits after a
+// non-timeout receive error before retrying, so a channel that fails
+// synchronously (bad channel ID, transport down) can't busy-spin its
+// goroutine.
+const (
+	receiveErrorBackoffMin = 100 * time.Millisecond
+	receiveErrorBackoffMax = 5 * time.Second
+)
+
+// Handler processes a single Message pulled in by a ConsumerGroup. A
+// non-nil error triggers the group's configured requeue/dead-letter
+// behavior.
+type Handler func(ctx context.Context, msg Message) error
+
+// GroupStats is a point-in-time snapshot of a ConsumerGroup's backpressure
+// state, keyed by channel ID.
+type GroupStats struct {
+	// Buffered is the number of messages currently queued for dispatch,
+	// per channel.
+	Buffered map[uint32]int
+	// DeadLettered is the number of messages per channel whose handler
+	// returned an error and were not requeued.
+	DeadLettered map[uint32]int
+}
+
+// groupConfig holds ConsumerGroup-specific tunables.
+type groupConfig struct {
+	workers    int
+	queueDepth int
+	requeue    bool
+
+	consumerOpts    []Option
+	instrumentation *Instrumentation
+}
+
+// GroupOption configures a ConsumerGroup.
+type GroupOption func(*groupConfig)
+
+// WithWorkers sets the number of goroutines dispatching messages to the
+// Handler. The default is 1 worker per channel.
+func WithWorkers(n int) GroupOption {
+	return func(c *groupConfig) {
+		c.workers = n
+	}
+}
+
+// WithQueueDepth sets each channel's share of the buffered queue feeding
+// the worker pool. All channels share one queue holding n*len(channelIDs)
+// messages, so a busy channel can use more than n slots. The default is 64.
+func WithQueueDepth(n int) GroupOption {
+	return func(c *groupConfig) {
+		c.queueDepth = n
+	}
+}
+
+// WithRequeueOnError makes a ConsumerGroup requeue a message (once) when
+// its Handler returns an error, instead of dead-lettering it immediately.
+func WithRequeueOnError(requeue bool) GroupOption {
+	return func(c *groupConfig) {
+		c.requeue = requeue
+	}
+}
+
+// WithConsumerOptions passes opts through to every per-channel Consumer a
+// ConsumerGroup creates internally, in addition to any instrumentation
+// wired up via WithGroupInstrumentation.
+func WithConsumerOptions(opts ...Option) GroupOption {
+	return func(c *groupConfig) {
+		c.consumerOpts = append(c.consumerOpts, opts...)
+	}
+}
+
+// WithGroupInstrumentation attaches an Instrumentation to every
+// per-channel Consumer a ConsumerGroup creates (so dmxp_messages_received_total
+// and friends get samples), and additionally reports
+// dmxp_consumer_buffer_utilization from the group's own dispatch queue
+// occupancy, which a lone Consumer has no visibility into.
+func WithGroupInstrumentation(in *Instrumentation) GroupOption {
+	return func(c *groupConfig) {
+		c.instrumentation = in
+	}
+}
+
+// dispatched wraps a Message with the bookkeeping a ConsumerGroup needs to
+// requeue it at most once.
+type dispatched struct {
+	msg     Message
+	channel uint32
+	retried bool
+}
+
+// ConsumerGroup fans in messages from several channels, each served by its
+// own Consumer, and dispatches them to a Handler through a bounded worker
+// pool. It mirrors the consumer-group pattern found in Kafka client
+// libraries: one connection per partition/channel, one logical stream of
+// handled messages out.
+type ConsumerGroup struct {
+	channelIDs []uint32
+	handler    Handler
+	cfg        groupConfig
+
+	mu          sync.Mutex
+	buffered    map[uint32]int
+	deadLetters map[uint32]int
+
+	queue  chan dispatched
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewConsumerGroup creates consumers for each of channelIDs and prepares
+// them for Run. handler is invoked for every received message.
+func NewConsumerGroup(channelIDs []uint32, handler Handler, opts ...GroupOption) (*ConsumerGroup, error) {
+	if len(channelIDs) == 0 {
+		return nil, fmt.Errorf("dmxp: NewConsumerGroup requires at least one channel")
+	}
+
+	cfg := groupConfig{workers: len(channelIDs), queueDepth: 64}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.workers < 1 {
+		cfg.workers = 1
+	}
+
+	return &ConsumerGroup{
+		channelIDs:  append([]uint32(nil), channelIDs...),
+		handler:     handler,
+		cfg:         cfg,
+		buffered:    make(map[uint32]int, len(channelIDs)),
+		deadLetters: make(map[uint32]int, len(channelIDs)),
+		queue:       make(chan dispatched, cfg.queueDepth*len(channelIDs)),
+	}, nil
+}
+
+// Run starts one receive goroutine per channel plus the configured number
+// of dispatch workers, and blocks until ctx is canceled or Close is
+// called. Run returns the first non-context error encountered while
+// opening a channel's Consumer, if any.
+func (g *ConsumerGroup) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	g.cancel = cancel
+	defer cancel()
+
+	opts := append([]Option(nil), g.cfg.consumerOpts...)
+	if g.cfg.instrumentation != nil {
+		opts = append(opts, WithInstrumentation(g.cfg.instrumentation))
+	}
+
+	consumers := make([]*Consumer, 0, len(g.channelIDs))
+	for _, id := range g.channelIDs {
+		c, err := NewConsumer(id, opts...)
+		if err != nil {
+			for _, opened := range consumers {
+				opened.Close()
+			}
+			return fmt.Errorf("dmxp: opening consumer for channel %d: %w", id, err)
+		}
+		consumers = append(consumers, c)
+	}
+	defer func() {
+		for _, c := range consumers {
+			c.Close()
+		}
+	}()
+
+	for _, c := range consumers {
+		g.wg.Add(1)
+		go g.receiveLoop(ctx, c)
+	}
+
+	for i := 0; i < g.cfg.workers; i++ {
+		g.wg.Add(1)
+		go g.dispatchLoop(ctx)
+	}
+
+	<-ctx.Done()
+	g.wg.Wait()
+	return nil
+}
+
+// Close cancels the context Run is blocked on and returns without waiting;
+// Run itself returns once its goroutines have exited.
+func (g *ConsumerGroup) Close() {
+	if g.cancel != nil {
+		g.cancel()
+	}
+}
+
+// Stats returns a snapshot of per-channel backpressure and dead-letter
+// counts.
+func (g *ConsumerGroup) Stats() GroupStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	buffered := make(map[uint32]int, len(g.buffered))
+	for k, v := range g.buffered {
+		buffered[k] = v
+	}
+	dead := make(map[uint32]int, len(g.deadLetters))
+	for k, v := range g.deadLetters {
+		dead[k] = v
+	}
+	return GroupStats{
+		Buffered:     buffered,
+		DeadLettered: dead,
+	}
+}
+
+func (g *ConsumerGroup) receiveLoop(ctx context.Context, c *Consumer) {
+	defer g.wg.Done()
+
+	backoff := receiveErrorBackoffMin
+	for {
+		msg, err := c.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			log.Printf("dmxp: consumer group: receive on channel %d failed: %v (retrying in %s)", c.channelID, err, backoff)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+			if backoff *= 2; backoff > receiveErrorBackoffMax {
+				backoff = receiveErrorBackoffMax
+			}
+			continue
+		}
+		backoff = receiveErrorBackoffMin
+
+		select {
+		case g.queue <- dispatched{msg: msg, channel: msg.ChannelID}:
+			g.mu.Lock()
+			g.buffered[msg.ChannelID]++
+			buffered := g.buffered[msg.ChannelID]
+			g.mu.Unlock()
+			g.reportBufferUtilization(msg.ChannelID, buffered)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reportBufferUtilization publishes a channel's share of the dispatch
+// queue as a fraction of the per-channel queue depth requested via
+// WithQueueDepth. The group's queue is shared across channels, so this is
+// an approximation of occupancy, not a hard per-channel capacity.
+func (g *ConsumerGroup) reportBufferUtilization(channelID uint32, buffered int) {
+	if g.cfg.instrumentation == nil || g.cfg.queueDepth <= 0 {
+		return
+	}
+	g.cfg.instrumentation.SetBufferUtilization(channelID, float64(buffered)/float64(g.cfg.queueDepth))
+}
+
+func (g *ConsumerGroup) dispatchLoop(ctx context.Context) {
+	defer g.wg.Done()
+
+	for {
+		select {
+		case d := <-g.queue:
+			g.mu.Lock()
+			g.buffered[d.channel]--
+			buffered := g.buffered[d.channel]
+			g.mu.Unlock()
+			g.reportBufferUtilization(d.channel, buffered)
+
+			if err := g.handler(ctx, d.msg); err != nil {
+				if g.cfg.requeue && !d.retried {
+					d.retried = true
+					select {
+					case g.queue <- d:
+						g.mu.Lock()
+						g.buffered[d.channel]++
+						buffered := g.buffered[d.channel]
+						g.mu.Unlock()
+						g.reportBufferUtilization(d.channel, buffered)
+					case <-ctx.Done():
+						return
+					}
+					continue
+				}
+				g.mu.Lock()
+				g.deadLetters[d.channel]++
+				g.mu.Unlock()
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
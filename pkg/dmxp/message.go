@@ -0,0 +1,27 @@
+package dmxp
+
+// Message is the pure-Go representation of a message received from (or to
+// be sent to) the shared-memory transport. It deliberately exposes no FFI
+// types so callers never need to import the cgo bridge themselves.
+type Message struct {
+	// MessageID uniquely identifies the message within the transport.
+	MessageID uint64
+	// TimestampNS is the sender-side send time, in nanoseconds since the
+	// Unix epoch.
+	TimestampNS uint64
+	// ChannelID is the channel the message was published on.
+	ChannelID uint32
+	// MessageType is an application-defined discriminator.
+	MessageType uint32
+	// SenderPID is the OS process ID of the sender.
+	SenderPID uint32
+	// SenderRuntime identifies which language runtime produced the
+	// message (e.g. Python, Rust, Go), assigned by the transport.
+	SenderRuntime uint16
+	// Flags carries transport-level bits (see flag* constants).
+	Flags uint16
+	// Payload is the message body. It is safe to retain past the call
+	// that produced it, except inside a ZeroCopyMessage, where it is only
+	// valid until Release is called.
+	Payload []byte
+}
@@ -0,0 +1,125 @@
+package dmxp
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Instrumentation holds the Prometheus collectors a Consumer reports
+// through, plus enough bookkeeping to answer liveness checks such as
+// "has a message arrived recently". Share one Instrumentation across every
+// Consumer in a process so their metrics aggregate under one registry.
+type Instrumentation struct {
+	messagesReceived  *prometheus.CounterVec
+	receiveErrors     *prometheus.CounterVec
+	receiveLatency    *prometheus.HistogramVec
+	consumerLag       *prometheus.GaugeVec
+	bufferUtilization *prometheus.GaugeVec
+
+	lastReceivedUnixNano int64
+}
+
+// NewInstrumentation creates an Instrumentation and registers its
+// collectors with reg. Pass prometheus.DefaultRegisterer to match a
+// promhttp.Handler() served on /metrics.
+func NewInstrumentation(reg prometheus.Registerer) *Instrumentation {
+	in := &Instrumentation{
+		messagesReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "dmxp_messages_received_total",
+			Help: "Messages successfully received, by channel and sender runtime.",
+		}, []string{"channel", "runtime"}),
+		receiveErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "dmxp_receive_errors_total",
+			Help: "Receive errors, by symbolic FFI result code.",
+		}, []string{"code"}),
+		receiveLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "dmxp_receive_latency_seconds",
+			Help:    "End-to-end latency from the sender's timestamp to Go-side receive.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"channel"}),
+		consumerLag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "dmxp_consumer_lag_seconds",
+			Help: "Latency of the most recently received message, by channel.",
+		}, []string{"channel"}),
+		bufferUtilization: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "dmxp_consumer_buffer_utilization",
+			Help: "Messages a ConsumerGroup has queued for dispatch per channel, as a fraction of its per-channel queue depth.",
+		}, []string{"channel"}),
+	}
+
+	reg.MustRegister(
+		in.messagesReceived,
+		in.receiveErrors,
+		in.receiveLatency,
+		in.consumerLag,
+		in.bufferUtilization,
+	)
+	return in
+}
+
+// recordReceive updates counters/histograms/gauges for a successfully
+// received message.
+func (in *Instrumentation) recordReceive(channelID uint32, runtime uint16, sentUnixNano uint64) {
+	channel := strconv.FormatUint(uint64(channelID), 10)
+	in.messagesReceived.WithLabelValues(channel, strconv.FormatUint(uint64(runtime), 10)).Inc()
+
+	now := time.Now()
+	atomic.StoreInt64(&in.lastReceivedUnixNano, now.UnixNano())
+
+	if sentUnixNano > 0 {
+		latency := now.Sub(time.Unix(0, int64(sentUnixNano))).Seconds()
+		in.receiveLatency.WithLabelValues(channel).Observe(latency)
+		in.consumerLag.WithLabelValues(channel).Set(latency)
+	}
+}
+
+// recordError increments the error counter with a symbolic label derived
+// from err ("closed", "invalid_argument", "buffer_too_small"). An FFI code
+// this package has no name for is labelled with its number, and any other
+// error with "unknown". ErrTimeout is not recorded: a poll timeout is
+// expected idle behavior, not a failure.
+func (in *Instrumentation) recordError(err error) {
+	if errors.Is(err, ErrTimeout) {
+		return
+	}
+
+	code := "unknown"
+	switch {
+	case errors.Is(err, ErrClosed):
+		code = "closed"
+	case errors.Is(err, ErrInvalidArgument):
+		code = "invalid_argument"
+	default:
+		var ffiErr *ffiError
+		if errors.As(err, &ffiErr) {
+			if ffiErr.code == codeBufferTooSmall {
+				code = "buffer_too_small"
+			} else {
+				code = strconv.Itoa(int(ffiErr.code))
+			}
+		}
+	}
+	in.receiveErrors.WithLabelValues(code).Inc()
+}
+
+// LastReceivedAt returns the time of the most recent successfully
+// received message, or the zero Time if none has arrived yet.
+func (in *Instrumentation) LastReceivedAt() time.Time {
+	ns := atomic.LoadInt64(&in.lastReceivedUnixNano)
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// SetBufferUtilization reports a ConsumerGroup's per-channel dispatch
+// queue occupancy as a fraction of the per-channel queue depth. The
+// group's queue is shared across channels, so a busy channel can report a
+// value above 1.
+func (in *Instrumentation) SetBufferUtilization(channelID uint32, fraction float64) {
+	in.bufferUtilization.WithLabelValues(strconv.FormatUint(uint64(channelID), 10)).Set(fraction)
+}
@@ -0,0 +1,57 @@
+package dmxp
+
+import "time"
+
+// config holds the tunables shared by Consumer and Producer. It is built up
+// by Option values and is never exposed directly.
+type config struct {
+	pollInterval    time.Duration
+	initialBufCap   int
+	instrumentation *Instrumentation
+}
+
+func defaultConfig() config {
+	return config{
+		pollInterval:  100 * time.Millisecond,
+		initialBufCap: 1024,
+	}
+}
+
+// Option configures a Consumer or Producer. Options are applied in order,
+// so later options override earlier ones.
+type Option func(*config)
+
+// WithPollInterval sets the granularity at which Receive re-checks
+// ctx.Done() while waiting for a message. Smaller values make context
+// cancellation more responsive at the cost of more FFI calls while idle.
+// The default is 100ms.
+func WithPollInterval(d time.Duration) Option {
+	return func(c *config) {
+		c.pollInterval = d
+	}
+}
+
+// WithInitialBufferSize sets the starting size of the buffer used to
+// receive message payloads. The buffer grows automatically when a message
+// does not fit, so this only matters as a performance hint. The default is
+// 1024 bytes. n <= 0 is ignored and leaves the default (or a previous
+// WithInitialBufferSize call) in place, since a zero-length buffer can
+// never receive into and can never grow.
+func WithInitialBufferSize(n int) Option {
+	return func(c *config) {
+		if n <= 0 {
+			return
+		}
+		c.initialBufCap = n
+	}
+}
+
+// WithInstrumentation attaches an Instrumentation so a Consumer reports
+// Prometheus metrics for its receives. A Producer accepts the option but
+// records no metrics for Send. Without this option, no metrics are
+// recorded.
+func WithInstrumentation(in *Instrumentation) Option {
+	return func(c *config) {
+		c.instrumentation = in
+	}
+}
@@ -0,0 +1,94 @@
+package dmxp
+
+import (
+	"context"
+	"sync"
+	"unsafe"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Producer publishes messages for a single channel to the shared-memory
+// transport. A Producer is not safe for concurrent use by multiple
+// goroutines.
+type Producer struct {
+	channelID uint32
+	cfg       config
+
+	mu     sync.Mutex
+	handle unsafe.Pointer
+	closed bool
+}
+
+// NewProducer opens a producer handle for channelID. The returned Producer
+// must be closed with Close when no longer needed.
+func NewProducer(channelID uint32, opts ...Option) (*Producer, error) {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	handle, err := newProducerHandle(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Producer{
+		channelID: channelID,
+		cfg:       cfg,
+		handle:    handle,
+	}, nil
+}
+
+// Send publishes payload with the given messageType on the producer's
+// channel. The dmxp.send span started for this call (a child of the span
+// in ctx, if any) is propagated to the receiving consumer by prefixing
+// payload with a trace context header.
+func (p *Producer) Send(ctx context.Context, messageType uint32, payload []byte) error {
+	ctx, span := tracer().Start(ctx, "dmxp.send", trace.WithAttributes(
+		attribute.Int64("dmxp.channel_id", int64(p.channelID)),
+	))
+	defer span.End()
+
+	var flags uint16
+	body := payload
+	if header, ok := encodeTraceHeader(ctx); ok {
+		flags |= flagHasTraceContext
+		body = make([]byte, 0, traceHeaderLen+len(payload))
+		body = append(body, header[:]...)
+		body = append(body, payload...)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		span.RecordError(ErrClosed)
+		span.SetStatus(codes.Error, ErrClosed.Error())
+		return ErrClosed
+	}
+
+	if err := sendFrom(p.handle, messageType, flags, body); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+// Close releases the underlying producer handle. It is safe to call Close
+// more than once.
+func (p *Producer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	freeProducerHandle(p.handle)
+	p.handle = nil
+	return nil
+}
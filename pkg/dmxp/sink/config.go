@@ -0,0 +1,137 @@
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/neerajchowdary889/DMXP-MPMC/pkg/dmxp"
+)
+
+// Config describes the sinks a bridge daemon should forward received
+// messages to. It is loaded from YAML or JSON via LoadConfig.
+type Config struct {
+	Channels []uint32     `json:"channels" yaml:"channels"`
+	Sinks    []SinkConfig `json:"sinks" yaml:"sinks"`
+}
+
+// FilterConfig narrows which messages a SinkConfig applies to. Empty
+// slices match everything on that dimension.
+type FilterConfig struct {
+	ChannelIDs     []uint32 `json:"channel_ids,omitempty" yaml:"channel_ids,omitempty"`
+	MessageTypes   []uint32 `json:"message_types,omitempty" yaml:"message_types,omitempty"`
+	SenderRuntimes []uint16 `json:"sender_runtimes,omitempty" yaml:"sender_runtimes,omitempty"`
+}
+
+// SinkConfig is a single configured destination. Type selects which
+// type-specific fields are read; unused fields are ignored.
+type SinkConfig struct {
+	Type   string       `json:"type" yaml:"type"`
+	Filter FilterConfig `json:"filter,omitempty" yaml:"filter,omitempty"`
+
+	// file
+	Dir      string `json:"dir,omitempty" yaml:"dir,omitempty"`
+	Prefix   string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	MaxBytes int64  `json:"max_bytes,omitempty" yaml:"max_bytes,omitempty"`
+
+	// kafka
+	Brokers []string `json:"brokers,omitempty" yaml:"brokers,omitempty"`
+	Topic   string   `json:"topic,omitempty" yaml:"topic,omitempty"`
+
+	// nats
+	URL     string `json:"url,omitempty" yaml:"url,omitempty"`
+	Subject string `json:"subject,omitempty" yaml:"subject,omitempty"`
+
+	// http
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+}
+
+// LoadConfig reads a Config from a YAML or JSON file, chosen by the path's
+// extension (.yaml, .yml, or .json).
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("sink: reading %s: %w", path, err)
+	}
+
+	var cfg Config
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &cfg)
+	case ".json":
+		err = json.Unmarshal(data, &cfg)
+	default:
+		return Config{}, fmt.Errorf("sink: unsupported config extension %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("sink: parsing %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Build constructs the Sink for every entry in cfg.Sinks, wrapping each in
+// its configured Filter.
+func Build(cfg Config) (Fanout, error) {
+	sinks := make(Fanout, 0, len(cfg.Sinks))
+	for i, sc := range cfg.Sinks {
+		s, err := buildOne(sc)
+		if err != nil {
+			sinks.Close()
+			return nil, fmt.Errorf("sink: building sinks[%d] (%s): %w", i, sc.Type, err)
+		}
+		sinks = append(sinks, WithFilter(s, buildFilter(sc.Filter)))
+	}
+	return sinks, nil
+}
+
+func buildOne(sc SinkConfig) (Sink, error) {
+	switch sc.Type {
+	case "stdout":
+		return NewStdout(os.Stdout), nil
+	case "file":
+		return NewFile(sc.Dir, sc.Prefix, sc.MaxBytes)
+	case "kafka":
+		return NewKafka(sc.Brokers, sc.Topic), nil
+	case "nats":
+		return NewNATS(sc.URL, sc.Subject)
+	case "http":
+		return NewHTTP(sc.Endpoint), nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", sc.Type)
+	}
+}
+
+func buildFilter(fc FilterConfig) Filter {
+	if len(fc.ChannelIDs) == 0 && len(fc.MessageTypes) == 0 && len(fc.SenderRuntimes) == 0 {
+		return nil
+	}
+
+	channelIDs := toSet(fc.ChannelIDs)
+	messageTypes := toSet(fc.MessageTypes)
+	senderRuntimes := toSet(fc.SenderRuntimes)
+
+	return func(msg dmxp.Message) bool {
+		if len(channelIDs) > 0 && !channelIDs[msg.ChannelID] {
+			return false
+		}
+		if len(messageTypes) > 0 && !messageTypes[msg.MessageType] {
+			return false
+		}
+		if len(senderRuntimes) > 0 && !senderRuntimes[msg.SenderRuntime] {
+			return false
+		}
+		return true
+	}
+}
+
+func toSet[T comparable](values []T) map[T]bool {
+	set := make(map[T]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,99 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/neerajchowdary889/DMXP-MPMC/pkg/dmxp"
+)
+
+// fileSink appends one JSON line per message to a file, rotating to a new
+// numbered file once the current one reaches maxBytes.
+type fileSink struct {
+	dir      string
+	prefix   string
+	maxBytes int64
+
+	mu       sync.Mutex
+	f        *os.File
+	enc      *json.Encoder
+	written  int64
+	sequence int
+}
+
+// NewFile returns a Sink that writes newline-delimited JSON into dir,
+// rotating to <prefix>.<n>.jsonl once the active file reaches maxBytes.
+// maxBytes <= 0 disables rotation.
+func NewFile(dir, prefix string, maxBytes int64) (Sink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("sink: creating %s: %w", dir, err)
+	}
+
+	fs := &fileSink{dir: dir, prefix: prefix, maxBytes: maxBytes}
+	if err := fs.rotate(); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+func (fs *fileSink) rotate() error {
+	if fs.f != nil {
+		fs.f.Close()
+	}
+
+	path := filepath.Join(fs.dir, fmt.Sprintf("%s.%d.jsonl", fs.prefix, fs.sequence))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("sink: opening %s: %w", path, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("sink: stat %s: %w", path, err)
+	}
+
+	fs.f = f
+	fs.enc = json.NewEncoder(f)
+	fs.written = info.Size()
+	fs.sequence++
+	return nil
+}
+
+func (fs *fileSink) Write(_ context.Context, msg dmxp.Message) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if fs.maxBytes > 0 && fs.written >= fs.maxBytes {
+		if err := fs.rotate(); err != nil {
+			return err
+		}
+	}
+
+	before := fs.written
+	if err := fs.enc.Encode(messageJSON(msg)); err != nil {
+		return err
+	}
+
+	info, err := fs.f.Stat()
+	if err != nil {
+		fs.written = before
+		return nil
+	}
+	fs.written = info.Size()
+	return nil
+}
+
+func (fs *fileSink) Close() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if fs.f == nil {
+		return nil
+	}
+	return fs.f.Close()
+}
@@ -0,0 +1,55 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/neerajchowdary889/DMXP-MPMC/pkg/dmxp"
+)
+
+// defaultHTTPSinkTimeout bounds each POST so a slow downstream endpoint
+// can't stall the dispatch worker that owns this sink indefinitely.
+const defaultHTTPSinkTimeout = 10 * time.Second
+
+// httpSink POSTs each message as a JSON body to a fixed URL.
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTP returns a Sink that POSTs each Message as JSON to url.
+func NewHTTP(url string) Sink {
+	return &httpSink{url: url, client: &http.Client{Timeout: defaultHTTPSinkTimeout}}
+}
+
+func (s *httpSink) Write(ctx context.Context, msg dmxp.Message) error {
+	body, err := json.Marshal(messageJSON(msg))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sink: http post to %s: unexpected status %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	return nil
+}
@@ -0,0 +1,44 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	kafka "github.com/segmentio/kafka-go"
+
+	"github.com/neerajchowdary889/DMXP-MPMC/pkg/dmxp"
+)
+
+// kafkaSink publishes each message as a JSON value, keyed by channel ID,
+// to a single Kafka topic.
+type kafkaSink struct {
+	writer *kafka.Writer
+}
+
+// NewKafka returns a Sink that publishes to topic on the given brokers.
+func NewKafka(brokers []string, topic string) Sink {
+	return &kafkaSink{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    topic,
+			Balancer: &kafka.Hash{},
+		},
+	}
+}
+
+func (s *kafkaSink) Write(ctx context.Context, msg dmxp.Message) error {
+	value, err := json.Marshal(messageJSON(msg))
+	if err != nil {
+		return err
+	}
+
+	return s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(strconv.FormatUint(uint64(msg.ChannelID), 10)),
+		Value: value,
+	})
+}
+
+func (s *kafkaSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,39 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/neerajchowdary889/DMXP-MPMC/pkg/dmxp"
+)
+
+// natsSink publishes each message as JSON to a fixed NATS subject.
+type natsSink struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// NewNATS connects to url and returns a Sink that publishes to subject.
+func NewNATS(url, subject string) (Sink, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, fmt.Errorf("sink: connecting to nats at %s: %w", url, err)
+	}
+	return &natsSink{conn: conn, subject: subject}, nil
+}
+
+func (s *natsSink) Write(_ context.Context, msg dmxp.Message) error {
+	value, err := json.Marshal(messageJSON(msg))
+	if err != nil {
+		return err
+	}
+	return s.conn.Publish(s.subject, value)
+}
+
+func (s *natsSink) Close() error {
+	s.conn.Close()
+	return nil
+}
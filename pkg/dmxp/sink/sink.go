@@ -0,0 +1,72 @@
+// Package sink forwards messages received from a dmxp.ConsumerGroup to
+// downstream systems (stdout, files, Kafka, NATS, HTTP endpoints), with an
+// optional filter in front of each destination.
+package sink
+
+import (
+	"context"
+
+	"github.com/neerajchowdary889/DMXP-MPMC/pkg/dmxp"
+)
+
+// Sink forwards a received Message to some downstream system. Write must
+// be safe to call from multiple goroutines if the Sink is shared across a
+// ConsumerGroup's dispatch workers.
+type Sink interface {
+	Write(ctx context.Context, msg dmxp.Message) error
+	Close() error
+}
+
+// Filter reports whether msg should be forwarded to a Sink. A nil Filter
+// matches everything.
+type Filter func(msg dmxp.Message) bool
+
+// filtered wraps a Sink so Write is a no-op for messages the Filter
+// rejects.
+type filtered struct {
+	Sink
+	filter Filter
+}
+
+// WithFilter returns a Sink that only forwards messages matching filter to
+// next. A nil filter returns next unchanged.
+func WithFilter(next Sink, filter Filter) Sink {
+	if filter == nil {
+		return next
+	}
+	return &filtered{Sink: next, filter: filter}
+}
+
+func (f *filtered) Write(ctx context.Context, msg dmxp.Message) error {
+	if !f.filter(msg) {
+		return nil
+	}
+	return f.Sink.Write(ctx, msg)
+}
+
+// Fanout forwards every message to all of sinks, continuing past
+// individual errors and returning the first one encountered.
+type Fanout []Sink
+
+// Write forwards msg to every sink in the set.
+func (f Fanout) Write(ctx context.Context, msg dmxp.Message) error {
+	var firstErr error
+	for _, s := range f {
+		if err := s.Write(ctx, msg); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Close closes every sink in the set, continuing past individual errors
+// and returning the first one encountered.
+func (f Fanout) Close() error {
+	var firstErr error
+	for _, s := range f {
+		if err := s.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,57 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/neerajchowdary889/DMXP-MPMC/pkg/dmxp"
+)
+
+// stdoutSink writes one JSON object per received message, matching the
+// original go_demo's print-every-message behavior.
+type stdoutSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewStdout returns a Sink that writes each Message as a line of JSON to
+// w.
+func NewStdout(w io.Writer) Sink {
+	return &stdoutSink{enc: json.NewEncoder(w)}
+}
+
+func (s *stdoutSink) Write(_ context.Context, msg dmxp.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(messageJSON(msg))
+}
+
+func (s *stdoutSink) Close() error {
+	return nil
+}
+
+// messageRecord is the wire shape written by every built-in sink;
+// Payload is base64-encoded by encoding/json's []byte handling.
+type messageRecord struct {
+	MessageID     uint64 `json:"message_id"`
+	TimestampNS   uint64 `json:"timestamp_ns"`
+	ChannelID     uint32 `json:"channel_id"`
+	MessageType   uint32 `json:"message_type"`
+	SenderPID     uint32 `json:"sender_pid"`
+	SenderRuntime uint16 `json:"sender_runtime"`
+	Payload       []byte `json:"payload"`
+}
+
+func messageJSON(msg dmxp.Message) messageRecord {
+	return messageRecord{
+		MessageID:     msg.MessageID,
+		TimestampNS:   msg.TimestampNS,
+		ChannelID:     msg.ChannelID,
+		MessageType:   msg.MessageType,
+		SenderPID:     msg.SenderPID,
+		SenderRuntime: msg.SenderRuntime,
+		Payload:       msg.Payload,
+	}
+}
@@ -0,0 +1,94 @@
+package dmxp
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies this package's spans in OTel exporters.
+const tracerName = "github.com/neerajchowdary889/DMXP-MPMC/pkg/dmxp"
+
+// flagHasTraceContext marks a message whose payload is prefixed with a
+// traceHeaderLen-byte trace context header (see encodeTraceHeader).
+const flagHasTraceContext uint16 = 1 << 0
+
+// traceHeaderLen is 16 bytes of W3C trace ID + 8 bytes of span ID + 1 byte
+// of trace flags, mirroring the binary layout of a traceparent header
+// without the dashes and hex encoding.
+const traceHeaderLen = 16 + 8 + 1
+
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// encodeTraceHeader extracts the span context carried by ctx, via the
+// globally configured propagator, and packs it into traceHeaderLen raw
+// bytes suitable for prefixing onto a message payload. It returns ok=false
+// if ctx carries no usable trace context.
+func encodeTraceHeader(ctx context.Context) (header [traceHeaderLen]byte, ok bool) {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	traceparent := carrier.Get("traceparent")
+	if traceparent == "" {
+		return header, false
+	}
+
+	// version-traceid-spanid-flags, e.g. "00-<32 hex>-<16 hex>-01".
+	var version string
+	var traceIDHex, spanIDHex, flagsHex string
+	if _, err := fmt.Sscanf(traceparent, "%2s-%32s-%16s-%2s", &version, &traceIDHex, &spanIDHex, &flagsHex); err != nil {
+		return header, false
+	}
+
+	traceID, err := hex.DecodeString(traceIDHex)
+	if err != nil || len(traceID) != 16 {
+		return header, false
+	}
+	spanID, err := hex.DecodeString(spanIDHex)
+	if err != nil || len(spanID) != 8 {
+		return header, false
+	}
+	flags, err := hex.DecodeString(flagsHex)
+	if err != nil || len(flags) != 1 {
+		return header, false
+	}
+
+	copy(header[0:16], traceID)
+	copy(header[16:24], spanID)
+	header[24] = flags[0]
+	return header, true
+}
+
+// decodeTraceHeader is the inverse of encodeTraceHeader: it rebuilds a
+// traceparent string from the raw header bytes and extracts it into ctx
+// via the globally configured propagator.
+func decodeTraceHeader(ctx context.Context, header []byte) context.Context {
+	if len(header) != traceHeaderLen {
+		return ctx
+	}
+
+	traceparent := fmt.Sprintf("00-%s-%s-%s",
+		hex.EncodeToString(header[0:16]),
+		hex.EncodeToString(header[16:24]),
+		hex.EncodeToString(header[24:25]),
+	)
+
+	carrier := propagation.MapCarrier{"traceparent": traceparent}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+func messageSpanAttributes(channelID uint32, messageID uint64, senderPID uint32, senderRuntime uint16) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int64("dmxp.channel_id", int64(channelID)),
+		attribute.Int64("dmxp.message_id", int64(messageID)),
+		attribute.Int64("dmxp.sender_pid", int64(senderPID)),
+		attribute.Int64("dmxp.sender_runtime", int64(senderRuntime)),
+	}
+}
@@ -0,0 +1,191 @@
+package dmxp
+
+import (
+	"context"
+	"sync"
+	"unsafe"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// slabRing is a fixed set of C.malloc'd buffers rotated across successive
+// receives. Slot i%N is only ever written to once the caller that
+// borrowed it (i-N)%N calls back has called Release, so the Rust side
+// never overwrites a slab the Go side is still reading.
+type slabRing struct {
+	ptrs     []unsafe.Pointer
+	tokens   []chan struct{} // capacity 1; a slot is free when its token is present
+	slabSize int
+	next     int
+}
+
+func newSlabRing(n, slabSize int) *slabRing {
+	r := &slabRing{
+		ptrs:     make([]unsafe.Pointer, n),
+		tokens:   make([]chan struct{}, n),
+		slabSize: slabSize,
+	}
+	for i := range r.ptrs {
+		r.ptrs[i] = allocSlab(slabSize)
+		r.tokens[i] = make(chan struct{}, 1)
+		r.tokens[i] <- struct{}{}
+	}
+	return r
+}
+
+// acquire blocks until the next slot in rotation is free (i.e. the
+// previous borrower released it) or ctx is done.
+func (r *slabRing) acquire(ctx context.Context) (slot int, err error) {
+	slot = r.next
+	r.next = (r.next + 1) % len(r.ptrs)
+
+	select {
+	case <-r.tokens[slot]:
+		return slot, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func (r *slabRing) release(slot int) {
+	r.tokens[slot] <- struct{}{}
+}
+
+func (r *slabRing) close() {
+	for _, p := range r.ptrs {
+		freeSlab(p)
+	}
+}
+
+// ZeroCopyMessage is a Message whose Payload aliases memory owned by a
+// ZeroCopyConsumer's slab ring instead of a copy on the Go heap.
+//
+// Safety: Payload is only valid until Release is called, and Release must
+// be called exactly once per ZeroCopyMessage, on every code path
+// (including errors), or the consumer's ring will eventually deadlock
+// waiting for a slot that is never freed. Once Release runs, the Rust
+// side may overwrite Payload's backing memory at any time — do not read
+// it, slice it further, or retain it past that call.
+type ZeroCopyMessage struct {
+	Message
+	Release func()
+}
+
+// ZeroCopyConsumer is a Consumer variant whose Receive path avoids the
+// per-message payload copy by handing callers a slice aliasing a
+// preallocated ring of C.malloc'd slabs, à la libmemif's Go bindings.
+type ZeroCopyConsumer struct {
+	*Consumer
+	ring *slabRing
+}
+
+// NewZeroCopyConsumer opens a consumer for channelID backed by a ring of
+// ringSize slabs, each slabSize bytes. ringSize should be at least 2 so
+// the transport can be writing one slab while the previous one is still
+// being processed.
+func NewZeroCopyConsumer(channelID uint32, ringSize, slabSize int, opts ...Option) (*ZeroCopyConsumer, error) {
+	c, err := NewConsumer(channelID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ZeroCopyConsumer{
+		Consumer: c,
+		ring:     newSlabRing(ringSize, slabSize),
+	}, nil
+}
+
+// ReceiveZeroCopy blocks until a message arrives, ctx is done, or the
+// consumer is closed, same as Consumer.Receive, but returns a
+// ZeroCopyMessage whose Payload aliases ring memory instead of a fresh
+// allocation. See ZeroCopyMessage's doc comment for the safety rules.
+func (z *ZeroCopyConsumer) ReceiveZeroCopy(ctx context.Context) (ZeroCopyMessage, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return ZeroCopyMessage{}, err
+		}
+
+		slot, err := z.ring.acquire(ctx)
+		if err != nil {
+			return ZeroCopyMessage{}, err
+		}
+
+		n, meta, err := z.receiveOnceInto(z.ring.ptrs[slot], z.ring.slabSize)
+		if err == ErrTimeout {
+			z.ring.release(slot)
+			continue
+		}
+		if ffiErr, ok := err.(*ffiError); ok && ffiErr.code == codeBufferTooSmall {
+			z.ring.release(slot)
+			return ZeroCopyMessage{}, z.traceReceiveError(ctx, &ffiError{op: "dmxp_consumer_receive_ext", code: codeBufferTooSmall})
+		}
+		if err != nil {
+			z.ring.release(slot)
+			return ZeroCopyMessage{}, z.traceReceiveError(ctx, err)
+		}
+
+		payload := unsafe.Slice((*byte)(z.ring.ptrs[slot]), n)
+
+		spanCtx := ctx
+		if meta.flags&flagHasTraceContext != 0 && len(payload) >= traceHeaderLen {
+			spanCtx = decodeTraceHeader(ctx, payload[:traceHeaderLen])
+			payload = payload[traceHeaderLen:]
+		}
+
+		_, span := tracer().Start(spanCtx, "dmxp.receive", trace.WithAttributes(
+			messageSpanAttributes(meta.channelID, meta.messageID, meta.senderPID, meta.senderRuntime)...,
+		))
+		span.SetStatus(codes.Ok, "")
+		span.End()
+
+		if z.cfg.instrumentation != nil {
+			z.cfg.instrumentation.recordReceive(meta.channelID, meta.senderRuntime, meta.timestampNS)
+		}
+
+		var once sync.Once
+		release := func() {
+			once.Do(func() { z.ring.release(slot) })
+		}
+
+		return ZeroCopyMessage{
+			Message: Message{
+				MessageID:     meta.messageID,
+				TimestampNS:   meta.timestampNS,
+				ChannelID:     meta.channelID,
+				MessageType:   meta.messageType,
+				SenderPID:     meta.senderPID,
+				SenderRuntime: meta.senderRuntime,
+				Flags:         meta.flags,
+				Payload:       payload,
+			},
+			Release: release,
+		}, nil
+	}
+}
+
+// receiveOnceInto is the slab-ring analogue of Consumer.receiveOnce.
+func (z *ZeroCopyConsumer) receiveOnceInto(slab unsafe.Pointer, slabSize int) (int, ffiMeta, error) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	if z.closed {
+		return 0, ffiMeta{}, ErrClosed
+	}
+
+	timeoutMs := int32(z.cfg.pollInterval / 1e6)
+	if timeoutMs <= 0 {
+		timeoutMs = 1
+	}
+
+	return receiveIntoPtr(z.handle, timeoutMs, slab, slabSize)
+}
+
+// Close releases the consumer handle and every slab in the ring. Any
+// ZeroCopyMessage whose Release has not yet been called has its Payload
+// invalidated immediately.
+func (z *ZeroCopyConsumer) Close() error {
+	err := z.Consumer.Close()
+	z.ring.close()
+	return err
+}
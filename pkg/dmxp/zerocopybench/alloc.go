@@ -0,0 +1,25 @@
+// Package zerocopybench benchmarks the allocate+copy step Consumer.Receive
+// performs to hand callers an owned payload against the equivalent
+// unsafe.Slice aliasing step ReceiveZeroCopy performs instead. It lives
+// outside package dmxp so its test binary doesn't link against the
+// dmxp_kvcache transport library: dmxp's own cgo file (ffi.go) requires it
+// to be built, but this benchmark only needs a plain C.malloc/C.free slab,
+// so it stays runnable without the Rust transport present.
+package zerocopybench
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+import "unsafe"
+
+// allocSlab and freeSlab mirror package dmxp's helpers of the same name,
+// duplicated here so this package has no dependency on dmxp's ffi.go (and
+// the -ldmxp_kvcache LDFLAGS it carries).
+func allocSlab(size int) unsafe.Pointer {
+	return C.malloc(C.size_t(size))
+}
+
+func freeSlab(p unsafe.Pointer) {
+	C.free(p)
+}
@@ -0,0 +1,45 @@
+package zerocopybench
+
+import (
+	"testing"
+	"unsafe"
+)
+
+// These benchmarks isolate the allocate+copy step Consumer.Receive
+// performs to hand callers an owned payload from the equivalent
+// unsafe.Slice aliasing step ReceiveZeroCopy performs instead, so they run
+// without the Rust transport present. See dmxp.ZeroCopyMessage for the
+// safety rules the zero-copy path trades away for this speedup.
+
+func BenchmarkReceiveCopy1KB(b *testing.B)  { benchmarkReceiveCopy(b, 1024) }
+func BenchmarkReceiveCopy64KB(b *testing.B) { benchmarkReceiveCopy(b, 64*1024) }
+func BenchmarkReceiveCopy1MB(b *testing.B)  { benchmarkReceiveCopy(b, 1024*1024) }
+
+func BenchmarkReceiveZeroCopy1KB(b *testing.B)  { benchmarkReceiveZeroCopy(b, 1024) }
+func BenchmarkReceiveZeroCopy64KB(b *testing.B) { benchmarkReceiveZeroCopy(b, 64*1024) }
+func BenchmarkReceiveZeroCopy1MB(b *testing.B)  { benchmarkReceiveZeroCopy(b, 1024*1024) }
+
+func benchmarkReceiveCopy(b *testing.B, size int) {
+	src := make([]byte, size)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(size))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst := make([]byte, len(src))
+		copy(dst, src)
+	}
+}
+
+func benchmarkReceiveZeroCopy(b *testing.B, size int) {
+	slab := allocSlab(size)
+	defer freeSlab(slab)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(size))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		payload := unsafe.Slice((*byte)(slab), size)
+		_ = payload
+	}
+}